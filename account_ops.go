@@ -0,0 +1,52 @@
+package microstellar
+
+import "github.com/stellar/go/build"
+
+// MergeAccount merges sourceSeed's account into destAddress, closing sourceSeed's
+// account and transferring its remaining balance (minus the transaction fee)
+// to destAddress. Use the Signer opt if sourceSeed should be an address
+// signed for by other seeds.
+func (ms *MicroStellar) MergeAccount(sourceSeed string, destAddress string, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.AccountMerge(build.Destination{AddressOrSeed: destAddress}))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
+
+// BumpSequence advances sourceSeed's account sequence number to bumpTo, which
+// must be greater than the account's current sequence number. This is used to
+// invalidate any transactions pre-signed against an older sequence number.
+// Use the Signer opt if sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) BumpSequence(sourceSeed string, bumpTo int64, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.BumpSequence(uint64(bumpTo)))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
+
+// SetData sets key to value in sourceSeed's account data, Stellar's on-chain
+// key/value store. Use the Signer opt if sourceSeed should be an address
+// signed for by other seeds.
+func (ms *MicroStellar) SetData(sourceSeed string, key string, value []byte, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.SetData(key, value))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
+
+// DeleteData removes key from sourceSeed's account data. Use the Signer opt if
+// sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) DeleteData(sourceSeed string, key string, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.ClearData(key))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
@@ -0,0 +1,13 @@
+package microstellar
+
+import "github.com/stellar/go/build"
+
+// buildAsset converts a microstellar Asset into the build.Asset mutator the
+// stellar/go build package expects for DEX and path-payment operations.
+func buildAsset(asset *Asset) build.Asset {
+	if asset.IsNative() {
+		return build.Asset{Native: true}
+	}
+
+	return build.Asset{Code: asset.Code, Issuer: asset.Issuer}
+}
@@ -0,0 +1,76 @@
+package microstellar
+
+import "github.com/stellar/go/build"
+
+// ManageOffer creates, updates, or deletes an offer on Stellar's built-in
+// decentralized exchange, selling the "selling" asset for the "buying" asset
+// at the given price. A zero offerID creates a new offer; a non-zero offerID
+// updates an existing one (or deletes it, if amount is "0"). Use the Signer
+// opt if sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) ManageOffer(sourceSeed string, selling, buying *Asset, amount, price string, offerID uint64, opts ...Opt) error {
+	rate := build.Rate{
+		Selling: buildAsset(selling),
+		Buying:  buildAsset(buying),
+		Price:   build.PriceFromString(price),
+	}
+
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.CreateOffer(rate, amount, build.OfferID(offerID)))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
+
+// CreatePassiveOffer creates a passive offer on the DEX: one that never takes
+// another offer of the same price, only ever being taken itself. Use the
+// Signer opt if sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) CreatePassiveOffer(sourceSeed string, selling, buying *Asset, amount, price string, opts ...Opt) error {
+	rate := build.Rate{
+		Selling: buildAsset(selling),
+		Buying:  buildAsset(buying),
+		Price:   build.PriceFromString(price),
+	}
+
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.CreatePassiveOffer(rate, amount))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
+
+// PathPayment sends destAmount of destAsset to destAddr, debiting sourceSeed up
+// to sendMax of sendAsset and converting across the DEX along path (as found by
+// FindPaymentPaths). Use the Signer opt if sourceSeed should be an address
+// signed for by other seeds.
+func (ms *MicroStellar) PathPayment(sourceSeed string, sendAsset *Asset, sendMax string, destAddr string, destAsset *Asset, destAmount string, path []*Asset, opts ...Opt) error {
+	hops := make([]build.Asset, len(path))
+	for i, asset := range path {
+		hops[i] = buildAsset(asset)
+	}
+
+	paymentMuts := []interface{}{
+		build.Destination{AddressOrSeed: destAddr},
+		build.PayWithPath{
+			Asset:     buildAsset(sendAsset),
+			MaxAmount: sendMax,
+			Path:      hops,
+		},
+	}
+
+	if destAsset.IsNative() {
+		paymentMuts = append(paymentMuts, build.NativeAmount{Amount: destAmount})
+	} else {
+		paymentMuts = append(paymentMuts, build.CreditAmount{Code: destAsset.Code, Issuer: destAsset.Issuer, Amount: destAmount})
+	}
+
+	op := build.Payment(paymentMuts...)
+
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), op)
+	tx.Sign(o.signOrDefault(sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
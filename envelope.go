@@ -0,0 +1,127 @@
+package microstellar
+
+import (
+	"errors"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+)
+
+// TxEnvelope is an unsubmitted Stellar transaction that has been built (and
+// possibly partially signed) but not yet sent to Horizon. It can be passed
+// between machines as base64 XDR or as a human-readable txrep document,
+// picking up additional signatures along the way, which enables offline
+// and multi-party signing workflows.
+type TxEnvelope struct {
+	networkName string
+	network     build.Network
+	e           xdr.TransactionEnvelope
+}
+
+// Base64 returns the envelope serialized as base64 XDR, the format Horizon
+// accepts for submission.
+func (te *TxEnvelope) Base64() (string, error) {
+	return xdr.MarshalBase64(te.e)
+}
+
+// TxRep returns the envelope rendered as a SEP-0011 txrep document.
+func (te *TxEnvelope) TxRep() (string, error) {
+	return encodeTxRep(te.e)
+}
+
+// ParseTxRep parses a txrep document (as produced by TxEnvelope.TxRep) back into
+// a TxEnvelope for the given network, ready for more signatures or submission.
+func ParseTxRep(networkName, txrep string) (*TxEnvelope, error) {
+	e, err := decodeTxRep(txrep)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxEnvelope{networkName: networkName, network: networkForName(networkName), e: e}, nil
+}
+
+// ParseEnvelope parses a base64 XDR transaction envelope for the given network.
+func ParseEnvelope(networkName, envelopeB64 string) (*TxEnvelope, error) {
+	var e xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeB64, &e); err != nil {
+		return nil, err
+	}
+
+	return &TxEnvelope{networkName: networkName, network: networkForName(networkName), e: e}, nil
+}
+
+// BuildUnsigned builds a transaction without signing or submitting it, returning
+// a TxEnvelope that can be serialized, passed around for signatures, and
+// eventually submitted with SubmitEnvelope. Callers must pass WithSequence to
+// supply a sequence number fetched ahead of time: unlike MicroStellar's other
+// helpers, BuildUnsigned never falls back to fetching one live from Horizon,
+// since doing so would defeat the point of building offline.
+func (ms *MicroStellar) BuildUnsigned(sourceSeed string, muts []build.TransactionMutator, opts ...Opt) (*TxEnvelope, error) {
+	o := newOptions(opts)
+	if !o.hasSequence {
+		return nil, errors.New("microstellar: BuildUnsigned requires WithSequence")
+	}
+
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), muts...)
+
+	if tx.Err() != nil {
+		return nil, tx.Err()
+	}
+
+	txe, err := tx.builder.Sign()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TxEnvelope{networkName: ms.networkName, network: tx.network, e: txe.E}, nil
+}
+
+// SignEnvelope adds signatures from seeds to envelope, returning the updated envelope.
+// The original envelope is left untouched, so a partially-signed envelope can be
+// handed to multiple signers in parallel and the results merged by submitting
+// whichever copy ends up with enough signatures.
+func (ms *MicroStellar) SignEnvelope(envelope *TxEnvelope, seeds ...string) (*TxEnvelope, error) {
+	e := envelope.e
+	e.Signatures = append([]xdr.DecoratedSignature(nil), envelope.e.Signatures...)
+
+	hash, err := network.HashTransaction(&e.Tx, envelope.network.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, seed := range seeds {
+		kp, err := keypair.Parse(seed)
+		if err != nil {
+			return nil, err
+		}
+
+		full, ok := kp.(*keypair.Full)
+		if !ok {
+			return nil, errors.New("microstellar: SignEnvelope requires a secret seed, not an address")
+		}
+
+		sig, err := full.SignDecorated(hash[:])
+		if err != nil {
+			return nil, err
+		}
+
+		e.Signatures = append(e.Signatures, sig)
+	}
+
+	return &TxEnvelope{networkName: envelope.networkName, network: envelope.network, e: e}, nil
+}
+
+// SubmitEnvelope submits a previously built and signed envelope to Horizon.
+func (ms *MicroStellar) SubmitEnvelope(envelope *TxEnvelope) error {
+	txeB64, err := envelope.Base64()
+	if err != nil {
+		return err
+	}
+
+	tx := ms.newTx()
+	_, err = tx.GetClient().SubmitTransaction(txeB64)
+	return err
+}
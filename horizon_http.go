@@ -0,0 +1,33 @@
+package microstellar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/stellar/go/clients/horizon"
+)
+
+// horizonGet issues a GET request against client's Horizon server for path
+// (e.g. "/paths?...") and unmarshals the JSON response into v. It exists
+// because some Horizon endpoints (path finding, SSE streams) have no
+// counterpart in the old stellar/go horizon.Client, which only wraps the
+// account/transaction/operation submission calls MicroStellar's core helpers need.
+func horizonGet(client *horizon.Client, path string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, client.URL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("microstellar: GET %s%s: %s", client.URL, path, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -10,13 +10,17 @@
 //
 // In the methods below, "sourceSeed" is typically the private key of the account that needs to sign the transaction.
 //
-// Most method signatures end with "signers ...string", which lets you add multiple signers to the transaction.
-// If you use "signers", then sourceSeed isn't used to sign -- it can be an address instead of a seed.
+// Most method signatures take a trailing "opts ...Opt", which lets you customize the
+// transaction (extra signers via Signer, timebounds, base fee, sequence number, memo).
+// If you use the Signer opt, then sourceSeed isn't used to sign -- it can be an address
+// instead of a seed.
 //
 // You can use ErrorString(...) to extract the Horizon error from a returned error.
 package microstellar
 
 import (
+	"net/http"
+
 	"github.com/stellar/go/build"
 	"github.com/stellar/go/keypair"
 )
@@ -26,14 +30,44 @@ import (
 type MicroStellar struct {
 	networkName string
 	fake        bool
+
+	horizonURL string
+	httpClient *http.Client
+}
+
+// ClientOpt configures a MicroStellar client created by New.
+type ClientOpt func(*MicroStellar)
+
+// WithHorizonURL points the client at a Horizon server other than the default
+// for networkName, e.g. a private Horizon deployment or a local stellar-core +
+// Horizon dev stack.
+func WithHorizonURL(url string) ClientOpt {
+	return func(ms *MicroStellar) {
+		ms.horizonURL = url
+	}
 }
 
-// New returns a new MicroStellar client connected to networkName ("test", "public")
-func New(networkName string) *MicroStellar {
-	return &MicroStellar{
+// WithHTTPClient makes the client issue all of its Horizon requests through c,
+// instead of http.DefaultClient. This is what makes the client testable with a
+// recording/replaying or otherwise mocked transport (see NewVCRTransport).
+func WithHTTPClient(c *http.Client) ClientOpt {
+	return func(ms *MicroStellar) {
+		ms.httpClient = c
+	}
+}
+
+// New returns a new MicroStellar client connected to networkName ("test", "public", or "fake").
+func New(networkName string, opts ...ClientOpt) *MicroStellar {
+	ms := &MicroStellar{
 		networkName: networkName,
 		fake:        networkName == "fake",
 	}
+
+	for _, opt := range opts {
+		opt(ms)
+	}
+
+	return ms
 }
 
 // CreateKeyPair generates a new random key pair.
@@ -47,22 +81,19 @@ func (ms *MicroStellar) CreateKeyPair() (*KeyPair, error) {
 }
 
 // FundAccount creates a new account out of address by funding it with lumens
-// from sourceSeed. The minimum funding amount today is 0.5 XLM. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) FundAccount(sourceSeed string, address string, amount string, signers ...string) error {
+// from sourceSeed. The minimum funding amount today is 0.5 XLM. Use the Signer
+// opt if sourceSeed should be an address signed for by other seeds, and
+// WithTimebounds/WithBaseFee/WithSequence/WithMemoHash/WithMemoReturn to
+// customize the transaction, e.g. for offline signing.
+func (ms *MicroStellar) FundAccount(sourceSeed string, address string, amount string, opts ...Opt) error {
 	payment := build.CreateAccount(
 		build.Destination{AddressOrSeed: address},
 		build.NativeAmount{Amount: amount})
 
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), payment)
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), payment)
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
@@ -73,7 +104,7 @@ func (ms *MicroStellar) LoadAccount(address string) (*Account, error) {
 		return newAccount(), nil
 	}
 
-	tx := NewTx(ms.networkName)
+	tx := ms.newTx()
 	account, err := tx.GetClient().LoadAccount(address)
 
 	if err != nil {
@@ -89,7 +120,8 @@ func (ms *MicroStellar) PayNative(sourceSeed string, targetAddress string, amoun
 }
 
 // Pay lets you create more advanced payment transactions (e.g., pay with credit assets, set memo, etc.)
-func (ms *MicroStellar) Pay(payment *Payment) error {
+// Use opts to additionally customize the transaction's timebounds, base fee, or sequence number.
+func (ms *MicroStellar) Pay(payment *Payment, opts ...Opt) error {
 	txMuts := []build.TransactionMutator{}
 
 	paymentMuts := []interface{}{
@@ -111,13 +143,15 @@ func (ms *MicroStellar) Pay(payment *Payment) error {
 	}
 
 	txMuts = append(txMuts, build.Payment(paymentMuts...))
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(payment.sourceSeed), txMuts...)
+
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(payment.sourceSeed), txMuts...)
 
 	if len(payment.signerSeeds) > 0 {
 		tx.Sign(payment.signerSeeds...)
 	} else {
-		tx.Sign(payment.sourceSeed)
+		tx.Sign(o.signOrDefault(payment.sourceSeed)...)
 	}
 
 	tx.Submit()
@@ -125,104 +159,74 @@ func (ms *MicroStellar) Pay(payment *Payment) error {
 }
 
 // CreateTrustLine creates a trustline from sourceSeed to asset, with the specified trust limit. An empty
-// limit string indicates no limit. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) CreateTrustLine(sourceSeed string, asset *Asset, limit string, signers ...string) error {
-	tx := NewTx(ms.networkName)
+// limit string indicates no limit. Use the Signer opt if sourceSeed should be an address signed for by
+// other seeds.
+func (ms *MicroStellar) CreateTrustLine(sourceSeed string, asset *Asset, limit string, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
 
 	if limit == "" {
-		tx.Build(sourceAccount(sourceSeed), build.Trust(asset.Code, asset.Issuer))
-	} else {
-		tx.Build(sourceAccount(sourceSeed), build.Trust(asset.Code, asset.Issuer, build.Limit(limit)))
-	}
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
+		tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.Trust(asset.Code, asset.Issuer))
 	} else {
-		tx.Sign(sourceSeed)
+		tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.Trust(asset.Code, asset.Issuer, build.Limit(limit)))
 	}
 
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
 
-// RemoveTrustLine removes an trustline from sourceSeed to an asset. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) RemoveTrustLine(sourceSeed string, asset *Asset, signers ...string) error {
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), build.RemoveTrust(asset.Code, asset.Issuer))
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+// RemoveTrustLine removes an trustline from sourceSeed to an asset. Use the Signer opt if sourceSeed
+// should be an address signed for by other seeds.
+func (ms *MicroStellar) RemoveTrustLine(sourceSeed string, asset *Asset, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.RemoveTrust(asset.Code, asset.Issuer))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
 
-// SetMasterWeight changes the master weight of sourceSeed. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) SetMasterWeight(sourceSeed string, weight uint32, signers ...string) error {
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), build.MasterWeight(weight))
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+// SetMasterWeight changes the master weight of sourceSeed. Use the Signer opt if sourceSeed
+// should be an address signed for by other seeds.
+func (ms *MicroStellar) SetMasterWeight(sourceSeed string, weight uint32, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.MasterWeight(weight))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
 
-// AddSigner adds signerAddress as a signer to sourceSeed's account with weight signerWeight. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) AddSigner(sourceSeed string, signerAddress string, signerWeight uint32, signers ...string) error {
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), build.AddSigner(signerAddress, signerWeight))
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+// AddSigner adds signerAddress as a signer to sourceSeed's account with weight signerWeight. Use the
+// Signer opt if sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) AddSigner(sourceSeed string, signerAddress string, signerWeight uint32, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.AddSigner(signerAddress, signerWeight))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
 
-// RemoveSigner removes signerAddress as a signer from sourceSeed's account. If "signers" exist,
-// then sourceSeed can be an address, and the transaction will be signed with the list of seeds
-// in "signers."
-func (ms *MicroStellar) RemoveSigner(sourceSeed string, signerAddress string, signers ...string) error {
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), build.RemoveSigner(signerAddress))
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+// RemoveSigner removes signerAddress as a signer from sourceSeed's account. Use the Signer opt if
+// sourceSeed should be an address signed for by other seeds.
+func (ms *MicroStellar) RemoveSigner(sourceSeed string, signerAddress string, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.RemoveSigner(signerAddress))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
 
-// SetThresholds sets the signing thresholds for the account. If "signers" exists then sourceSeed
-// can be an address, and the transaction will be signed with the list of seeds in "signers."
-func (ms *MicroStellar) SetThresholds(sourceSeed string, low, medium, high uint32, signers ...string) error {
-	tx := NewTx(ms.networkName)
-	tx.Build(sourceAccount(sourceSeed), build.SetThresholds(low, medium, high))
-
-	if len(signers) > 0 {
-		tx.Sign(signers...)
-	} else {
-		tx.Sign(sourceSeed)
-	}
-
+// SetThresholds sets the signing thresholds for the account. Use the Signer opt if sourceSeed
+// should be an address signed for by other seeds.
+func (ms *MicroStellar) SetThresholds(sourceSeed string, low, medium, high uint32, opts ...Opt) error {
+	o := newOptions(opts)
+	tx := ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(sourceSeed), build.SetThresholds(low, medium, high))
+	tx.Sign(o.signOrDefault(sourceSeed)...)
 	tx.Submit()
 	return tx.Err()
 }
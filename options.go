@@ -0,0 +1,125 @@
+package microstellar
+
+import "github.com/stellar/go/build"
+
+// Options holds the optional, per-transaction settings that can be layered
+// onto any MicroStellar helper via Opt functions: explicit signers,
+// timebounds, a custom base fee, an explicit sequence number, and a memo.
+// The zero value means "use the defaults" (sign with sourceSeed, no
+// timebounds, the network's default base fee, and a live sequence number
+// fetched from Horizon).
+type Options struct {
+	signers []string
+
+	hasTimebounds bool
+	minTime       uint64
+	maxTime       uint64
+
+	hasBaseFee bool
+	baseFee    uint32
+
+	hasSequence bool
+	sequence    uint64
+
+	memoHash   *[32]byte
+	memoReturn *[32]byte
+}
+
+// Opt configures an Options value. Opts are applied in order, so a later Opt
+// overrides an earlier one that sets the same field. Signer opts are the
+// exception: they accumulate.
+type Opt func(*Options)
+
+// newOptions resolves a list of Opts into an Options value.
+func newOptions(opts []Opt) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Signer adds seed as an additional signer for the transaction. If at least
+// one Signer opt is given, sourceSeed itself is no longer used to sign --
+// mirroring the "signers" convention used throughout MicroStellar.
+func Signer(seed string) Opt {
+	return func(o *Options) {
+		o.signers = append(o.signers, seed)
+	}
+}
+
+// WithTimebounds restricts the transaction to being valid between minTime and
+// maxTime (Unix timestamps). A maxTime of 0 means "no expiration."
+func WithTimebounds(minTime, maxTime uint64) Opt {
+	return func(o *Options) {
+		o.hasTimebounds = true
+		o.minTime = minTime
+		o.maxTime = maxTime
+	}
+}
+
+// WithBaseFee sets the per-operation fee, in stroops, to pay for the transaction.
+func WithBaseFee(baseFee uint32) Opt {
+	return func(o *Options) {
+		o.hasBaseFee = true
+		o.baseFee = baseFee
+	}
+}
+
+// WithSequence sets an explicit sequence number for the transaction, skipping
+// the live Horizon fetch MicroStellar otherwise does. This is what makes
+// offline signing possible: the sequence number can be computed ahead of time
+// from a previously-seen account state.
+func WithSequence(sequence uint64) Opt {
+	return func(o *Options) {
+		o.hasSequence = true
+		o.sequence = sequence
+	}
+}
+
+// WithMemoHash attaches a MEMO_HASH memo to the transaction.
+func WithMemoHash(hash [32]byte) Opt {
+	return func(o *Options) {
+		o.memoHash = &hash
+	}
+}
+
+// WithMemoReturn attaches a MEMO_RETURN memo to the transaction.
+func WithMemoReturn(hash [32]byte) Opt {
+	return func(o *Options) {
+		o.memoReturn = &hash
+	}
+}
+
+// mutators turns the set options that map directly onto build.TransactionMutators
+// (timebounds, sequence, memo) into a mutator list to hand to Tx.Build.
+func (o *Options) mutators() []build.TransactionMutator {
+	var muts []build.TransactionMutator
+
+	if o.hasTimebounds {
+		muts = append(muts, build.Timebounds{MinTime: o.minTime, MaxTime: o.maxTime})
+	}
+
+	if o.hasSequence {
+		muts = append(muts, build.Sequence{Sequence: o.sequence})
+	}
+
+	if o.memoHash != nil {
+		muts = append(muts, build.MemoHash{Value: *o.memoHash})
+	}
+
+	if o.memoReturn != nil {
+		muts = append(muts, build.MemoReturn{Value: *o.memoReturn})
+	}
+
+	return muts
+}
+
+// signOrDefault returns the signers to use for a transaction: the explicit
+// signers collected via Signer opts, or sourceSeed itself if none were given.
+func (o *Options) signOrDefault(sourceSeed string) []string {
+	if len(o.signers) > 0 {
+		return o.signers
+	}
+	return []string{sourceSeed}
+}
@@ -0,0 +1,101 @@
+package microstellar
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Path describes one possible route a path payment could take to convert
+// sendAsset into destAsset, as returned by Horizon's /paths endpoint.
+type Path struct {
+	SourceAsset  *Asset
+	SourceAmount string
+	DestAsset    *Asset
+	DestAmount   string
+	Path         []*Asset
+}
+
+// horizonAsset mirrors the asset fields embedded in Horizon's /paths response records.
+type horizonAsset struct {
+	Type   string `json:"asset_type"`
+	Code   string `json:"asset_code"`
+	Issuer string `json:"asset_issuer"`
+}
+
+func (a horizonAsset) toAsset() *Asset {
+	if a.Type == "native" {
+		return &Asset{}
+	}
+	return &Asset{Code: a.Code, Issuer: a.Issuer}
+}
+
+// horizonPathRecord is a single record in Horizon's /paths response. Like the
+// source side, the destination asset is three flat scalar fields, not a
+// nested object.
+type horizonPathRecord struct {
+	SourceAssetType        string         `json:"source_asset_type"`
+	SourceAssetCode        string         `json:"source_asset_code"`
+	SourceAssetIssuer      string         `json:"source_asset_issuer"`
+	SourceAmount           string         `json:"source_amount"`
+	DestinationAssetType   string         `json:"destination_asset_type"`
+	DestinationAssetCode   string         `json:"destination_asset_code"`
+	DestinationAssetIssuer string         `json:"destination_asset_issuer"`
+	DestinationAmount      string         `json:"destination_amount"`
+	Path                   []horizonAsset `json:"path"`
+}
+
+// FindPaymentPaths finds possible paths for a path payment that sends destAmount
+// of destAsset to destAddr, paid for out of sourceAddr's balances. It queries
+// Horizon's /paths endpoint directly, since path-finding has no counterpart
+// among MicroStellar's transaction-building helpers.
+func (ms *MicroStellar) FindPaymentPaths(sourceAddr string, destAddr string, destAsset *Asset, destAmount string) ([]*Path, error) {
+	tx := ms.newTx()
+	client := tx.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("microstellar: FindPaymentPaths requires a live Horizon connection")
+	}
+
+	q := url.Values{}
+	q.Set("source_account", sourceAddr)
+	q.Set("destination_account", destAddr)
+	q.Set("destination_amount", destAmount)
+
+	if destAsset.IsNative() {
+		q.Set("destination_asset_type", "native")
+	} else {
+		q.Set("destination_asset_type", assetTypeForCode(destAsset.Code))
+		q.Set("destination_asset_code", destAsset.Code)
+		q.Set("destination_asset_issuer", destAsset.Issuer)
+	}
+
+	var page struct {
+		Embedded struct {
+			Records []horizonPathRecord `json:"records"`
+		} `json:"_embedded"`
+	}
+
+	if err := horizonGet(client, "/paths?"+q.Encode(), &page); err != nil {
+		return nil, err
+	}
+
+	paths := make([]*Path, 0, len(page.Embedded.Records))
+	for _, r := range page.Embedded.Records {
+		sourceAsset := horizonAsset{Type: r.SourceAssetType, Code: r.SourceAssetCode, Issuer: r.SourceAssetIssuer}
+		destAsset := horizonAsset{Type: r.DestinationAssetType, Code: r.DestinationAssetCode, Issuer: r.DestinationAssetIssuer}
+
+		hops := make([]*Asset, len(r.Path))
+		for i, h := range r.Path {
+			hops[i] = h.toAsset()
+		}
+
+		paths = append(paths, &Path{
+			SourceAsset:  sourceAsset.toAsset(),
+			SourceAmount: r.SourceAmount,
+			DestAsset:    destAsset.toAsset(),
+			DestAmount:   r.DestinationAmount,
+			Path:         hops,
+		})
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,191 @@
+package microstellar
+
+import (
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/xdr"
+)
+
+// Tx represents a single Stellar transaction as it moves through the
+// build -> sign -> submit pipeline. Errors are latched on the first
+// failing step, so callers can chain Build/Sign/Submit and only check
+// Err() once at the end.
+type Tx struct {
+	networkName string
+	network     build.Network
+	client      *horizon.Client
+
+	builder  *build.TransactionBuilder
+	envelope *build.TransactionEnvelopeBuilder
+
+	err error
+}
+
+// NewTx creates a new transaction for the given network ("test", "public", or "fake").
+func NewTx(networkName string) *Tx {
+	tx := &Tx{networkName: networkName, network: networkForName(networkName)}
+
+	switch networkName {
+	case "public":
+		tx.client = horizon.DefaultPublicNetClient
+	case "fake":
+		// No live Horizon client for the fake network.
+	default:
+		tx.client = horizon.DefaultTestNetClient
+	}
+
+	return tx
+}
+
+// newTx creates a new transaction for ms's network, using ms's configured
+// Horizon URL and/or HTTP client in place of the network's defaults. The
+// default horizon.Client singletons (horizon.DefaultTestNetClient/
+// DefaultPublicNetClient) are never mutated: a fresh copy is made first, so
+// that distinct MicroStellar instances -- and their WithHorizonURL/
+// WithHTTPClient overrides -- can't clobber each other or the process-wide
+// defaults.
+func (ms *MicroStellar) newTx() *Tx {
+	tx := NewTx(ms.networkName)
+
+	if tx.client == nil {
+		return tx
+	}
+
+	if ms.horizonURL == "" && ms.httpClient == nil {
+		return tx
+	}
+
+	client := *tx.client
+	if ms.horizonURL != "" {
+		client.URL = ms.horizonURL
+	}
+	if ms.httpClient != nil {
+		client.HTTP = ms.httpClient
+	}
+	tx.client = &client
+
+	return tx
+}
+
+// networkForName maps a MicroStellar network name to its Stellar network passphrase.
+func networkForName(networkName string) build.Network {
+	if networkName == "public" {
+		return build.PublicNetwork
+	}
+	return build.TestNetwork
+}
+
+// sourceAccount returns a TransactionMutator that sets the source account of a transaction.
+func sourceAccount(seed string) build.TransactionMutator {
+	return build.SourceAccount{AddressOrSeed: seed}
+}
+
+// GetClient returns the underlying Horizon client used by this transaction.
+func (tx *Tx) GetClient() *horizon.Client {
+	return tx.client
+}
+
+// Build assembles the transaction from source and the given mutators. If a
+// previous step has already failed, Build is a no-op.
+func (tx *Tx) Build(source build.TransactionMutator, muts ...build.TransactionMutator) {
+	tx.BuildWithOptions(nil, source, muts...)
+}
+
+// BuildWithOptions is like Build, but also applies o -- timebounds, an explicit
+// sequence number, a memo, and/or a custom base fee -- to the transaction. A
+// nil o behaves exactly like Build.
+func (tx *Tx) BuildWithOptions(o *Options, source build.TransactionMutator, muts ...build.TransactionMutator) {
+	if tx.err != nil {
+		return
+	}
+
+	allMuts := append([]build.TransactionMutator{source, tx.network}, muts...)
+	if o != nil {
+		allMuts = append(allMuts, o.mutators()...)
+	}
+
+	if (o == nil || !o.hasSequence) && tx.client != nil {
+		allMuts = append(allMuts, build.AutoSequence{SequenceProvider: tx.client})
+	}
+
+	builder, err := build.Transaction(allMuts...)
+	if err != nil {
+		tx.err = err
+		return
+	}
+
+	if o != nil && o.hasBaseFee {
+		builder.TX.Fee = xdr.Uint32(o.baseFee) * xdr.Uint32(len(builder.TX.Operations))
+	}
+
+	tx.builder = builder
+}
+
+// Sign signs the built transaction with the given seeds.
+func (tx *Tx) Sign(seeds ...string) {
+	if tx.err != nil {
+		return
+	}
+
+	envelope, err := tx.builder.Sign(seeds...)
+	if err != nil {
+		tx.err = err
+		return
+	}
+
+	tx.envelope = envelope
+}
+
+// Submit submits the signed transaction to Horizon.
+func (tx *Tx) Submit() {
+	if tx.err != nil {
+		return
+	}
+
+	txeB64, err := tx.envelope.Base64()
+	if err != nil {
+		tx.err = err
+		return
+	}
+
+	_, err = tx.client.SubmitTransaction(txeB64)
+	tx.err = err
+}
+
+// Err returns the first error encountered while building, signing, or submitting the transaction.
+func (tx *Tx) Err() error {
+	return tx.err
+}
+
+// Envelope returns the base64 XDR representation of the transaction envelope, including
+// any signatures collected so far. This is the wire format Horizon expects from
+// /transactions, and is the format used to move a transaction between SignEnvelope calls.
+// It works whether or not Sign has been called yet: an unsigned transaction is
+// still a valid (if signature-less) envelope, built straight from the
+// underlying build.TransactionBuilder.
+func (tx *Tx) Envelope() (string, error) {
+	e, err := tx.xdrEnvelope()
+	if err != nil {
+		return "", err
+	}
+
+	return xdr.MarshalBase64(e)
+}
+
+// xdrEnvelope returns the raw XDR envelope struct that Envelope serializes.
+func (tx *Tx) xdrEnvelope() (xdr.TransactionEnvelope, error) {
+	if tx.err != nil {
+		return xdr.TransactionEnvelope{}, tx.err
+	}
+
+	if tx.envelope != nil {
+		return tx.envelope.E, nil
+	}
+
+	unsigned, err := tx.builder.Sign()
+	if err != nil {
+		return xdr.TransactionEnvelope{}, err
+	}
+
+	return unsigned.E, nil
+}
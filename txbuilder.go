@@ -0,0 +1,142 @@
+package microstellar
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/build"
+)
+
+// maxOperationsPerTx is the most operations a single Stellar transaction can hold.
+const maxOperationsPerTx = 100
+
+// TxBuilder accumulates operations into a single Stellar transaction so
+// multiple changes -- e.g. create an account, fund it, establish a trust
+// line, and pay it -- can be submitted atomically as one transaction,
+// instead of one FundAccount/CreateTrustLine/Pay call per operation. The fee
+// is still charged per operation. A Stellar transaction holds at most maxOperationsPerTx operations;
+// adding one beyond that latches an error that Submit returns.
+//
+// Use MicroStellar.NewTxBuilder to create one.
+type TxBuilder struct {
+	ms         *MicroStellar
+	sourceSeed string
+	muts       []build.TransactionMutator
+	err        error
+}
+
+// NewTxBuilder returns a TxBuilder that will submit its accumulated operations
+// as a single transaction signed by sourceSeed.
+func (ms *MicroStellar) NewTxBuilder(sourceSeed string) *TxBuilder {
+	return &TxBuilder{ms: ms, sourceSeed: sourceSeed}
+}
+
+func (b *TxBuilder) add(mut build.TransactionMutator, err error) *TxBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	if len(b.muts) >= maxOperationsPerTx {
+		b.err = fmt.Errorf("microstellar: transaction would exceed the %d operation limit", maxOperationsPerTx)
+		return b
+	}
+
+	b.muts = append(b.muts, mut)
+	return b
+}
+
+// AddPayment adds a payment operation to the transaction.
+func (b *TxBuilder) AddPayment(targetAddress string, asset *Asset, amount string) *TxBuilder {
+	if asset.IsNative() {
+		return b.add(build.Payment(
+			build.Destination{AddressOrSeed: targetAddress},
+			build.NativeAmount{Amount: amount}), nil)
+	}
+
+	return b.add(build.Payment(
+		build.Destination{AddressOrSeed: targetAddress},
+		build.CreditAmount{Code: asset.Code, Issuer: asset.Issuer, Amount: amount}), nil)
+}
+
+// AddCreateAccount adds a create-account operation that funds a new address with amount lumens.
+func (b *TxBuilder) AddCreateAccount(address string, amount string) *TxBuilder {
+	return b.add(build.CreateAccount(
+		build.Destination{AddressOrSeed: address},
+		build.NativeAmount{Amount: amount}), nil)
+}
+
+// AddTrust adds a change-trust operation establishing a trust line to asset, up to limit.
+// An empty limit means no limit.
+func (b *TxBuilder) AddTrust(asset *Asset, limit string) *TxBuilder {
+	if limit == "" {
+		return b.add(build.Trust(asset.Code, asset.Issuer), nil)
+	}
+
+	return b.add(build.Trust(asset.Code, asset.Issuer, build.Limit(limit)), nil)
+}
+
+// AddSetOptions adds a set-options operation with the given mutators (e.g.
+// build.MasterWeight, build.AddSigner, build.SetThresholds).
+func (b *TxBuilder) AddSetOptions(opts ...build.SetOptionsMutator) *TxBuilder {
+	return b.add(build.SetOptions(opts...), nil)
+}
+
+// AddManageOffer adds a manage-offer operation to the transaction. A zero offerID
+// creates a new offer; a non-zero offerID updates (or, with a zero amount, deletes) an existing one.
+func (b *TxBuilder) AddManageOffer(selling, buying *Asset, amount, price string, offerID uint64) *TxBuilder {
+	rate := build.Rate{
+		Selling: buildAsset(selling),
+		Buying:  buildAsset(buying),
+		Price:   build.PriceFromString(price),
+	}
+
+	return b.add(build.CreateOffer(rate, amount, build.OfferID(offerID)), nil)
+}
+
+// AddAccountMerge adds an account-merge operation that closes sourceSeed's
+// account and transfers its remaining balance to destAddress.
+func (b *TxBuilder) AddAccountMerge(destAddress string) *TxBuilder {
+	return b.add(build.AccountMerge(build.Destination{AddressOrSeed: destAddress}), nil)
+}
+
+// AddBumpSequence adds a bump-sequence operation that advances the source account's
+// sequence number to bumpTo.
+func (b *TxBuilder) AddBumpSequence(bumpTo int64) *TxBuilder {
+	return b.add(build.BumpSequence(uint64(bumpTo)), nil)
+}
+
+// AddManageData adds a manage-data operation that sets key to value on the source
+// account. A nil value deletes key.
+func (b *TxBuilder) AddManageData(key string, value []byte) *TxBuilder {
+	if value == nil {
+		return b.add(build.ClearData(key), nil)
+	}
+
+	return b.add(build.SetData(key, value), nil)
+}
+
+// Submit builds, signs, and submits the accumulated operations as a single
+// transaction. Use the Signer opt if sourceSeed should be an address signed
+// for by other seeds, and WithTimebounds/WithBaseFee/WithSequence/
+// WithMemoHash/WithMemoReturn to customize the transaction, e.g. for offline
+// signing.
+func (b *TxBuilder) Submit(opts ...Opt) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	if len(b.muts) == 0 {
+		return nil
+	}
+
+	o := newOptions(opts)
+	tx := b.ms.newTx()
+	tx.BuildWithOptions(o, sourceAccount(b.sourceSeed), b.muts...)
+	tx.Sign(o.signOrDefault(b.sourceSeed)...)
+	tx.Submit()
+	return tx.Err()
+}
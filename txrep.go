@@ -0,0 +1,345 @@
+package microstellar
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/xdr"
+)
+
+// encodeTxRep renders a transaction envelope as a SEP-0011 txrep document: an
+// ordered list of "key: value" lines that's diffable and reviewable in a way
+// base64 XDR isn't. Only CreateAccount and Payment operations are expanded
+// field-by-field; every other operation type (including the trust, offer,
+// merge, bump-sequence and data operations microstellar builds) is recorded
+// by its XDR base64 so round-tripping still works.
+func encodeTxRep(e xdr.TransactionEnvelope) (string, error) {
+	var b strings.Builder
+	tx := e.Tx
+
+	fmt.Fprintf(&b, "tx.sourceAccount: %s\n", tx.SourceAccount.Address())
+	fmt.Fprintf(&b, "tx.fee: %d\n", tx.Fee)
+	fmt.Fprintf(&b, "tx.seqNum: %d\n", tx.SeqNum)
+
+	if tx.TimeBounds != nil {
+		fmt.Fprintf(&b, "tx.timeBounds._present: true\n")
+		fmt.Fprintf(&b, "tx.timeBounds.minTime: %d\n", tx.TimeBounds.MinTime)
+		fmt.Fprintf(&b, "tx.timeBounds.maxTime: %d\n", tx.TimeBounds.MaxTime)
+	} else {
+		fmt.Fprintf(&b, "tx.timeBounds._present: false\n")
+	}
+
+	if err := encodeTxRepMemo(&b, tx.Memo); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&b, "tx.operations.len: %d\n", len(tx.Operations))
+	for i, op := range tx.Operations {
+		if err := encodeTxRepOp(&b, i, op); err != nil {
+			return "", err
+		}
+	}
+
+	fmt.Fprintf(&b, "tx.signatures.len: %d\n", len(e.Signatures))
+	for i, sig := range e.Signatures {
+		fmt.Fprintf(&b, "tx.signatures[%d].hint: %x\n", i, sig.Hint)
+		fmt.Fprintf(&b, "tx.signatures[%d].signature: %x\n", i, sig.Signature)
+	}
+
+	return b.String(), nil
+}
+
+func encodeTxRepMemo(b *strings.Builder, memo xdr.Memo) error {
+	switch memo.Type {
+	case xdr.MemoTypeMemoNone:
+		fmt.Fprintf(b, "tx.memo.type: MEMO_NONE\n")
+	case xdr.MemoTypeMemoText:
+		fmt.Fprintf(b, "tx.memo.type: MEMO_TEXT\n")
+		fmt.Fprintf(b, "tx.memo.text: %q\n", *memo.Text)
+	case xdr.MemoTypeMemoId:
+		fmt.Fprintf(b, "tx.memo.type: MEMO_ID\n")
+		fmt.Fprintf(b, "tx.memo.id: %d\n", *memo.Id)
+	case xdr.MemoTypeMemoHash:
+		fmt.Fprintf(b, "tx.memo.type: MEMO_HASH\n")
+		fmt.Fprintf(b, "tx.memo.hash: %x\n", *memo.Hash)
+	case xdr.MemoTypeMemoReturn:
+		fmt.Fprintf(b, "tx.memo.type: MEMO_RETURN\n")
+		fmt.Fprintf(b, "tx.memo.retHash: %x\n", *memo.RetHash)
+	default:
+		return fmt.Errorf("txrep: unsupported memo type %v", memo.Type)
+	}
+
+	return nil
+}
+
+func encodeTxRepOp(b *strings.Builder, i int, op xdr.Operation) error {
+	prefix := fmt.Sprintf("tx.operations[%d].body", i)
+
+	if op.SourceAccount != nil {
+		fmt.Fprintf(b, "%s.sourceAccount: %s\n", prefix, op.SourceAccount.Address())
+	}
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		o := op.Body.CreateAccountOp
+		fmt.Fprintf(b, "%s.type: CREATE_ACCOUNT\n", prefix)
+		fmt.Fprintf(b, "%s.createAccountOp.destination: %s\n", prefix, o.Destination.Address())
+		fmt.Fprintf(b, "%s.createAccountOp.startingBalance: %d\n", prefix, o.StartingBalance)
+	case xdr.OperationTypePayment:
+		o := op.Body.PaymentOp
+		fmt.Fprintf(b, "%s.type: PAYMENT\n", prefix)
+		fmt.Fprintf(b, "%s.paymentOp.destination: %s\n", prefix, o.Destination.Address())
+		fmt.Fprintf(b, "%s.paymentOp.asset: %s\n", prefix, encodeTxRepAsset(o.Asset))
+		fmt.Fprintf(b, "%s.paymentOp.amount: %d\n", prefix, o.Amount)
+	default:
+		xdrB64, err := xdr.MarshalBase64(op)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%s.type: RAW\n", prefix)
+		fmt.Fprintf(b, "%s.raw: %s\n", prefix, xdrB64)
+	}
+
+	return nil
+}
+
+func encodeTxRepAsset(asset xdr.Asset) string {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		return "XLM"
+	}
+
+	return fmt.Sprintf("%s:%s", asset.GetCode(), asset.GetIssuer())
+}
+
+// decodeTxRep parses a SEP-0011 txrep document, as produced by encodeTxRep, back
+// into a transaction. It reads the fields encodeTxRep emits, in any order, and
+// ignores keys it doesn't know, which is sufficient for the round-trip (and
+// offline review/edit) workflow this is built for.
+func decodeTxRep(txrep string) (xdr.TransactionEnvelope, error) {
+	var e xdr.TransactionEnvelope
+
+	fields, err := parseTxRepFields(txrep)
+	if err != nil {
+		return e, err
+	}
+
+	sourceAccount, err := xdr.AddressToAccountId(fields["tx.sourceAccount"])
+	if err != nil {
+		return e, fmt.Errorf("txrep: invalid tx.sourceAccount: %w", err)
+	}
+	e.Tx.SourceAccount = sourceAccount
+
+	fee, err := strconv.ParseUint(fields["tx.fee"], 10, 32)
+	if err != nil {
+		return e, fmt.Errorf("txrep: invalid tx.fee: %w", err)
+	}
+	e.Tx.Fee = xdr.Uint32(fee)
+
+	seqNum, err := strconv.ParseInt(fields["tx.seqNum"], 10, 64)
+	if err != nil {
+		return e, fmt.Errorf("txrep: invalid tx.seqNum: %w", err)
+	}
+	e.Tx.SeqNum = xdr.SequenceNumber(seqNum)
+
+	if fields["tx.timeBounds._present"] == "true" {
+		minTime, _ := strconv.ParseUint(fields["tx.timeBounds.minTime"], 10, 64)
+		maxTime, _ := strconv.ParseUint(fields["tx.timeBounds.maxTime"], 10, 64)
+		e.Tx.TimeBounds = &xdr.TimeBounds{MinTime: xdr.TimePoint(minTime), MaxTime: xdr.TimePoint(maxTime)}
+	}
+
+	memo, err := decodeTxRepMemo(fields)
+	if err != nil {
+		return e, err
+	}
+	e.Tx.Memo = memo
+
+	numOps, err := strconv.Atoi(fields["tx.operations.len"])
+	if err != nil {
+		return e, fmt.Errorf("txrep: invalid tx.operations.len: %w", err)
+	}
+
+	for i := 0; i < numOps; i++ {
+		op, err := decodeTxRepOp(fields, i)
+		if err != nil {
+			return e, err
+		}
+		e.Tx.Operations = append(e.Tx.Operations, op)
+	}
+
+	numSigs, err := strconv.Atoi(fields["tx.signatures.len"])
+	if err != nil {
+		return e, fmt.Errorf("txrep: invalid tx.signatures.len: %w", err)
+	}
+
+	for i := 0; i < numSigs; i++ {
+		hint, err := decodeTxRepHex(fields[fmt.Sprintf("tx.signatures[%d].hint", i)])
+		if err != nil {
+			return e, err
+		}
+		sig, err := decodeTxRepHex(fields[fmt.Sprintf("tx.signatures[%d].signature", i)])
+		if err != nil {
+			return e, err
+		}
+
+		var decorated xdr.DecoratedSignature
+		copy(decorated.Hint[:], hint)
+		decorated.Signature = sig
+		e.Signatures = append(e.Signatures, decorated)
+	}
+
+	return e, nil
+}
+
+func decodeTxRepMemo(fields map[string]string) (xdr.Memo, error) {
+	switch fields["tx.memo.type"] {
+	case "MEMO_NONE", "":
+		return xdr.Memo{Type: xdr.MemoTypeMemoNone}, nil
+	case "MEMO_TEXT":
+		text, err := strconv.Unquote(fields["tx.memo.text"])
+		if err != nil {
+			return xdr.Memo{}, fmt.Errorf("txrep: invalid tx.memo.text: %w", err)
+		}
+		return xdr.Memo{Type: xdr.MemoTypeMemoText, Text: &text}, nil
+	case "MEMO_ID":
+		id, err := strconv.ParseUint(fields["tx.memo.id"], 10, 64)
+		if err != nil {
+			return xdr.Memo{}, fmt.Errorf("txrep: invalid tx.memo.id: %w", err)
+		}
+		xdrID := xdr.Uint64(id)
+		return xdr.Memo{Type: xdr.MemoTypeMemoId, Id: &xdrID}, nil
+	case "MEMO_HASH":
+		h, err := decodeTxRepHex(fields["tx.memo.hash"])
+		if err != nil {
+			return xdr.Memo{}, err
+		}
+		var xdrHash xdr.Hash
+		copy(xdrHash[:], h)
+		return xdr.Memo{Type: xdr.MemoTypeMemoHash, Hash: &xdrHash}, nil
+	case "MEMO_RETURN":
+		h, err := decodeTxRepHex(fields["tx.memo.retHash"])
+		if err != nil {
+			return xdr.Memo{}, err
+		}
+		var xdrHash xdr.Hash
+		copy(xdrHash[:], h)
+		return xdr.Memo{Type: xdr.MemoTypeMemoReturn, RetHash: &xdrHash}, nil
+	default:
+		return xdr.Memo{}, fmt.Errorf("txrep: unsupported tx.memo.type %q", fields["tx.memo.type"])
+	}
+}
+
+func decodeTxRepOp(fields map[string]string, i int) (xdr.Operation, error) {
+	var op xdr.Operation
+	prefix := fmt.Sprintf("tx.operations[%d].body", i)
+
+	if src, ok := fields[prefix+".sourceAccount"]; ok {
+		account, err := xdr.AddressToAccountId(src)
+		if err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.sourceAccount: %w", prefix, err)
+		}
+		op.SourceAccount = &account
+	}
+
+	switch fields[prefix+".type"] {
+	case "CREATE_ACCOUNT":
+		dest, err := xdr.AddressToAccountId(fields[prefix+".createAccountOp.destination"])
+		if err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.createAccountOp.destination: %w", prefix, err)
+		}
+		balance, err := strconv.ParseInt(fields[prefix+".createAccountOp.startingBalance"], 10, 64)
+		if err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.createAccountOp.startingBalance: %w", prefix, err)
+		}
+
+		op.Body.Type = xdr.OperationTypeCreateAccount
+		op.Body.CreateAccountOp = &xdr.CreateAccountOp{
+			Destination:     dest,
+			StartingBalance: xdr.Int64(balance),
+		}
+	case "PAYMENT":
+		dest, err := xdr.AddressToAccountId(fields[prefix+".paymentOp.destination"])
+		if err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.paymentOp.destination: %w", prefix, err)
+		}
+		asset, err := decodeTxRepAsset(fields[prefix+".paymentOp.asset"])
+		if err != nil {
+			return op, err
+		}
+		amount, err := strconv.ParseInt(fields[prefix+".paymentOp.amount"], 10, 64)
+		if err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.paymentOp.amount: %w", prefix, err)
+		}
+
+		op.Body.Type = xdr.OperationTypePayment
+		op.Body.PaymentOp = &xdr.PaymentOp{
+			Destination: dest,
+			Asset:       asset,
+			Amount:      xdr.Int64(amount),
+		}
+	case "RAW":
+		if err := xdr.SafeUnmarshalBase64(fields[prefix+".raw"], &op); err != nil {
+			return op, fmt.Errorf("txrep: invalid %s.raw: %w", prefix, err)
+		}
+	default:
+		return op, fmt.Errorf("txrep: unsupported %s.type %q", prefix, fields[prefix+".type"])
+	}
+
+	return op, nil
+}
+
+func decodeTxRepAsset(s string) (xdr.Asset, error) {
+	if s == "XLM" {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return xdr.Asset{}, fmt.Errorf("txrep: invalid asset %q", s)
+	}
+
+	return xdr.BuildAsset(assetTypeForCode(parts[0]), parts[1], parts[0])
+}
+
+func assetTypeForCode(code string) string {
+	if len(code) > 4 {
+		return "credit_alphanum12"
+	}
+	return "credit_alphanum4"
+}
+
+func decodeTxRepHex(s string) ([]byte, error) {
+	b := make([]byte, len(s)/2)
+	_, err := fmt.Sscanf(s, "%x", &b)
+	if err != nil {
+		return nil, fmt.Errorf("txrep: invalid hex %q: %w", s, err)
+	}
+	return b, nil
+}
+
+// parseTxRepFields splits a txrep document into its "key: value" pairs.
+func parseTxRepFields(txrep string) (map[string]string, error) {
+	fields := map[string]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(txrep))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("txrep: malformed line %q", line)
+		}
+
+		fields[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
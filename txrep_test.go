@@ -0,0 +1,106 @@
+package microstellar
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+)
+
+func mustAccountID(t *testing.T, address string) xdr.AccountId {
+	t.Helper()
+	id, err := xdr.AddressToAccountId(address)
+	if err != nil {
+		t.Fatalf("AddressToAccountId(%q): %v", address, err)
+	}
+	return id
+}
+
+func TestTxRepRoundTripPayment(t *testing.T) {
+	source := mustAccountID(t, "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ")
+	dest := mustAccountID(t, "GDQWI6FKB72DPOJE4CGYCFQZKRPQQIOYXRMZ5KEVGXMG6UUTGJMBCASH")
+
+	text := "hello"
+	envelope := xdr.TransactionEnvelope{
+		Tx: xdr.Transaction{
+			SourceAccount: source,
+			Fee:           100,
+			SeqNum:        42,
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoText, Text: &text},
+			Operations: []xdr.Operation{
+				{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypePayment,
+						PaymentOp: &xdr.PaymentOp{
+							Destination: dest,
+							Asset:       xdr.Asset{Type: xdr.AssetTypeAssetTypeNative},
+							Amount:      10000000,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rep, err := encodeTxRep(envelope)
+	if err != nil {
+		t.Fatalf("encodeTxRep: %v", err)
+	}
+
+	decoded, err := decodeTxRep(rep)
+	if err != nil {
+		t.Fatalf("decodeTxRep: %v\n--- txrep ---\n%s", err, rep)
+	}
+
+	reEncoded, err := encodeTxRep(decoded)
+	if err != nil {
+		t.Fatalf("re-encodeTxRep: %v", err)
+	}
+
+	if rep != reEncoded {
+		t.Fatalf("txrep round-trip mismatch:\n--- original ---\n%s\n--- re-encoded ---\n%s", rep, reEncoded)
+	}
+}
+
+func TestTxRepRoundTripCreateAccount(t *testing.T) {
+	source := mustAccountID(t, "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ")
+	dest := mustAccountID(t, "GDQWI6FKB72DPOJE4CGYCFQZKRPQQIOYXRMZ5KEVGXMG6UUTGJMBCASH")
+
+	envelope := xdr.TransactionEnvelope{
+		Tx: xdr.Transaction{
+			SourceAccount: source,
+			Fee:           100,
+			SeqNum:        7,
+			Memo:          xdr.Memo{Type: xdr.MemoTypeMemoNone},
+			Operations: []xdr.Operation{
+				{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypeCreateAccount,
+						CreateAccountOp: &xdr.CreateAccountOp{
+							Destination:     dest,
+							StartingBalance: 50000000,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rep, err := encodeTxRep(envelope)
+	if err != nil {
+		t.Fatalf("encodeTxRep: %v", err)
+	}
+
+	decoded, err := decodeTxRep(rep)
+	if err != nil {
+		t.Fatalf("decodeTxRep: %v\n--- txrep ---\n%s", err, rep)
+	}
+
+	reEncoded, err := encodeTxRep(decoded)
+	if err != nil {
+		t.Fatalf("re-encodeTxRep: %v", err)
+	}
+
+	if rep != reEncoded {
+		t.Fatalf("txrep round-trip mismatch:\n--- original ---\n%s\n--- re-encoded ---\n%s", rep, reEncoded)
+	}
+}
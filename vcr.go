@@ -0,0 +1,146 @@
+package microstellar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// vcrInteraction is a single recorded HTTP request/response pair.
+type vcrInteraction struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"request_body,omitempty"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header"`
+	Body        string      `json:"body"`
+}
+
+// vcrCassette is the on-disk format of a recorded session: an ordered list of
+// interactions, replayed in the order they were recorded.
+type vcrCassette struct {
+	Interactions []vcrInteraction `json:"interactions"`
+}
+
+// vcrTransport is an http.RoundTripper that records every request/response it
+// sees to a cassette file the first time it's used, and replays the same
+// cassette (matching requests by method+URL, in order) on every run after
+// that -- the same VCR-style pattern stellarnet's tests use to make Horizon
+// interactions deterministic and offline.
+type vcrTransport struct {
+	path string
+
+	mu       sync.Mutex
+	replay   bool
+	cassette vcrCassette
+	next     int
+
+	underlying http.RoundTripper
+}
+
+// NewVCRTransport returns an http.RoundTripper backed by the cassette at path.
+// If path doesn't exist yet, the transport records live requests (made
+// through http.DefaultTransport) to it; if it does, the transport replays
+// the recorded responses instead of making any live requests at all.
+func NewVCRTransport(path string) (http.RoundTripper, error) {
+	t := &vcrTransport{path: path, underlying: http.DefaultTransport}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return nil, fmt.Errorf("microstellar: invalid VCR cassette %s: %w", path, err)
+	}
+
+	t.replay = true
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.replay {
+		return t.roundTripReplay(req)
+	}
+	return t.roundTripRecord(req)
+}
+
+func (t *vcrTransport) roundTripReplay(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.next >= len(t.cassette.Interactions) {
+		return nil, fmt.Errorf("microstellar: VCR cassette %s has no more recorded interactions for %s %s", t.path, req.Method, req.URL)
+	}
+
+	interaction := t.cassette.Interactions[t.next]
+	if interaction.Method != req.Method || interaction.URL != req.URL.String() {
+		return nil, fmt.Errorf("microstellar: VCR cassette %s expected %s %s, got %s %s",
+			t.path, interaction.Method, interaction.URL, req.Method, req.URL)
+	}
+	t.next++
+
+	return &http.Response{
+		StatusCode: interaction.StatusCode,
+		Header:     interaction.Header,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(interaction.Body)),
+		Request:    req,
+	}, nil
+}
+
+func (t *vcrTransport) roundTripRecord(req *http.Request) (*http.Response, error) {
+	var reqBody string
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+		reqBody = string(b)
+	}
+
+	resp, err := t.underlying.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, vcrInteraction{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		RequestBody: reqBody,
+		StatusCode:  resp.StatusCode,
+		Header:      resp.Header,
+		Body:        string(body),
+	})
+	t.mu.Unlock()
+
+	return resp, t.save()
+}
+
+// save persists the cassette recorded so far to disk.
+func (t *vcrTransport) save() error {
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(t.path, data, 0644)
+}
@@ -0,0 +1,378 @@
+package microstellar
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/clients/horizon"
+)
+
+// errNoHorizonClient is returned by a Watch* call made against a network (like "fake")
+// that has no live Horizon client to stream from.
+var errNoHorizonClient = errors.New("microstellar: this network has no live Horizon connection to stream from")
+
+// streamHTTPError is returned by streamOnce when Horizon responds to a stream
+// request with a non-2xx status instead of opening an SSE stream -- a
+// mistyped address, an auth failure, or a wrong Horizon URL, none of which
+// will ever succeed by reconnecting.
+type streamHTTPError struct {
+	StatusCode int
+}
+
+func (e *streamHTTPError) Error() string {
+	return fmt.Sprintf("microstellar: stream request returned status %d", e.StatusCode)
+}
+
+// streamReconnectDelay is how long a watcher waits before reconnecting a
+// stream endpoint that was closed by the server or dropped by the network.
+const streamReconnectDelay = 2 * time.Second
+
+// watcher holds the state shared by every Watch* subscription: a way to stop
+// the stream, and the last error (if any) it encountered.
+type watcher struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func newWatcher() *watcher {
+	return &watcher{done: make(chan struct{})}
+}
+
+// Done stops the stream. It's safe to call more than once.
+func (w *watcher) Done() {
+	w.cancel()
+}
+
+// Err returns the last error (if any) the stream encountered. Errors from a
+// dropped or closed connection (including io.EOF) are recorded while the
+// watcher keeps reconnecting; a non-2xx response from Horizon is recorded and
+// ends the stream. A watcher that has hit no error returns nil.
+func (w *watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *watcher) setErr(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+// PaymentWatcher streams payment-related operations (payments, path payments,
+// account creation/merges) for an address as they happen.
+type PaymentWatcher struct {
+	*watcher
+	Ch <-chan *PaymentEvent
+}
+
+// PaymentEvent is a single payment-related operation delivered over a PaymentWatcher.
+type PaymentEvent struct {
+	Type        string
+	From        string
+	To          string
+	Amount      string
+	AssetCode   string
+	AssetIssuer string
+	PagingToken string
+}
+
+// TransactionWatcher streams transactions for an address as they happen.
+type TransactionWatcher struct {
+	*watcher
+	Ch <-chan *TransactionEvent
+}
+
+// TransactionEvent is a single transaction delivered over a TransactionWatcher.
+type TransactionEvent struct {
+	Hash           string
+	Ledger         int32
+	SourceAccount  string
+	EnvelopeXDR    string
+	ResultXDR      string
+	ResultMetaXDR  string
+	FeeCharged     int32
+	OperationCount int32
+	PagingToken    string
+}
+
+// OperationWatcher streams operations for an address as they happen.
+type OperationWatcher struct {
+	*watcher
+	Ch <-chan *OperationEvent
+}
+
+// OperationEvent is a single operation delivered over an OperationWatcher.
+type OperationEvent struct {
+	Type            string
+	TransactionHash string
+	SourceAccount   string
+	PagingToken     string
+}
+
+// LedgerWatcher streams ledgers as they close.
+type LedgerWatcher struct {
+	*watcher
+	Ch <-chan *LedgerEvent
+}
+
+// LedgerEvent is a single closed ledger delivered over a LedgerWatcher.
+type LedgerEvent struct {
+	Sequence    int32
+	Hash        string
+	ClosedAt    string
+	PagingToken string
+}
+
+// WatchPayments streams payment operations for address, starting just after
+// cursor (an empty cursor means "only new payments from now on"). Unlike
+// LoadAccount, which is a one-shot snapshot, this lets callers detect
+// incoming deposits without polling, backed by Horizon's
+// /accounts/{id}/payments Server-Sent Events stream.
+func (ms *MicroStellar) WatchPayments(address string, cursor string) (*PaymentWatcher, error) {
+	if cursor == "" {
+		cursor = "now"
+	}
+
+	ch := make(chan *PaymentEvent)
+	w, err := ms.watch("/accounts/"+address+"/payments", cursor, func(ctx context.Context, data []byte) error {
+		var record struct {
+			Type        string `json:"type"`
+			From        string `json:"from"`
+			To          string `json:"to"`
+			Amount      string `json:"amount"`
+			AssetCode   string `json:"asset_code"`
+			AssetIssuer string `json:"asset_issuer"`
+			PagingToken string `json:"paging_token"`
+		}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		select {
+		case ch <- &PaymentEvent{
+			Type: record.Type, From: record.From, To: record.To, Amount: record.Amount,
+			AssetCode: record.AssetCode, AssetIssuer: record.AssetIssuer, PagingToken: record.PagingToken,
+		}:
+		case <-ctx.Done():
+		}
+		return nil
+	}, func() { close(ch) })
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaymentWatcher{watcher: w, Ch: ch}, nil
+}
+
+// WatchTransactions streams transactions for address, starting just after cursor.
+func (ms *MicroStellar) WatchTransactions(address string, cursor string) (*TransactionWatcher, error) {
+	ch := make(chan *TransactionEvent)
+	w, err := ms.watch("/accounts/"+address+"/transactions", cursor, func(ctx context.Context, data []byte) error {
+		var record struct {
+			Hash           string `json:"hash"`
+			Ledger         int32  `json:"ledger"`
+			SourceAccount  string `json:"source_account"`
+			EnvelopeXDR    string `json:"envelope_xdr"`
+			ResultXDR      string `json:"result_xdr"`
+			ResultMetaXDR  string `json:"result_meta_xdr"`
+			FeeCharged     int32  `json:"fee_charged"`
+			OperationCount int32  `json:"operation_count"`
+			PagingToken    string `json:"paging_token"`
+		}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		select {
+		case ch <- &TransactionEvent{
+			Hash: record.Hash, Ledger: record.Ledger, SourceAccount: record.SourceAccount,
+			EnvelopeXDR: record.EnvelopeXDR, ResultXDR: record.ResultXDR, ResultMetaXDR: record.ResultMetaXDR,
+			FeeCharged: record.FeeCharged, OperationCount: record.OperationCount, PagingToken: record.PagingToken,
+		}:
+		case <-ctx.Done():
+		}
+		return nil
+	}, func() { close(ch) })
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionWatcher{watcher: w, Ch: ch}, nil
+}
+
+// WatchOperations streams operations for address, starting just after cursor.
+func (ms *MicroStellar) WatchOperations(address string, cursor string) (*OperationWatcher, error) {
+	ch := make(chan *OperationEvent)
+	w, err := ms.watch("/accounts/"+address+"/operations", cursor, func(ctx context.Context, data []byte) error {
+		var record struct {
+			Type            string `json:"type"`
+			TransactionHash string `json:"transaction_hash"`
+			SourceAccount   string `json:"source_account"`
+			PagingToken     string `json:"paging_token"`
+		}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		select {
+		case ch <- &OperationEvent{
+			Type: record.Type, TransactionHash: record.TransactionHash,
+			SourceAccount: record.SourceAccount, PagingToken: record.PagingToken,
+		}:
+		case <-ctx.Done():
+		}
+		return nil
+	}, func() { close(ch) })
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &OperationWatcher{watcher: w, Ch: ch}, nil
+}
+
+// WatchLedgers streams ledgers as they close, starting just after cursor.
+func (ms *MicroStellar) WatchLedgers(cursor string) (*LedgerWatcher, error) {
+	ch := make(chan *LedgerEvent)
+	w, err := ms.watch("/ledgers", cursor, func(ctx context.Context, data []byte) error {
+		var record struct {
+			Sequence    int32  `json:"sequence"`
+			Hash        string `json:"hash"`
+			ClosedAt    string `json:"closed_at"`
+			PagingToken string `json:"paging_token"`
+		}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+		select {
+		case ch <- &LedgerEvent{Sequence: record.Sequence, Hash: record.Hash, ClosedAt: record.ClosedAt, PagingToken: record.PagingToken}:
+		case <-ctx.Done():
+		}
+		return nil
+	}, func() { close(ch) })
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerWatcher{watcher: w, Ch: ch}, nil
+}
+
+// watch is the shared engine behind the exported Watch* methods: it opens a
+// Server-Sent Events connection to path on Horizon, decodes each "data:"
+// frame with decode, and transparently reconnects (picking up from the last
+// seen cursor) if the connection drops. decode is handed the watcher's ctx so
+// it can select on ctx.Done() instead of blocking forever delivering to a
+// channel nobody is reading anymore, letting Done() stop a watcher even
+// mid-delivery.
+func (ms *MicroStellar) watch(path string, cursor string, decode func(ctx context.Context, data []byte) error, closeCh func()) (*watcher, error) {
+	tx := ms.newTx()
+	client := tx.GetClient()
+	if client == nil {
+		return nil, errNoHorizonClient
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := newWatcher()
+	w.cancel = cancel
+
+	go func() {
+		defer closeCh()
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			nextCursor, err := streamOnce(ctx, client, path, cursor, decode)
+			if nextCursor != "" {
+				cursor = nextCursor
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				w.setErr(err)
+
+				var httpErr *streamHTTPError
+				if errors.As(err, &httpErr) {
+					cancel()
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(streamReconnectDelay):
+			}
+		}
+	}()
+
+	return w, nil
+}
+
+// streamOnce makes a single streaming GET request to path and reads SSE
+// frames from it until the connection ends, returning the last cursor seen.
+func streamOnce(ctx context.Context, client *horizon.Client, path string, cursor string, decode func(ctx context.Context, data []byte) error) (string, error) {
+	url := client.URL + path + "?stream=true"
+	if cursor != "" {
+		if strings.Contains(url, "?") {
+			url += "&cursor=" + cursor
+		} else {
+			url += "?cursor=" + cursor
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return cursor, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return cursor, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return cursor, &streamHTTPError{StatusCode: resp.StatusCode}
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	lastCursor := cursor
+
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			lastCursor = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data := strings.TrimPrefix(line, "data: ")
+			if data != `"hello"` {
+				if derr := decode(ctx, []byte(data)); derr != nil {
+					return lastCursor, derr
+				}
+			}
+		}
+
+		if err != nil {
+			return lastCursor, err
+		}
+	}
+}